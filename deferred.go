@@ -0,0 +1,144 @@
+package echozap
+
+import (
+	"sync"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// bufferedEntry is a log entry captured before DeferredLogger has a real
+// core to write to.
+type bufferedEntry struct {
+	entry  zapcore.Entry
+	fields []zapcore.Field
+}
+
+// deferredState is the state shared by a DeferredLogger and every core
+// produced from it via With, so that buffering and delegate installation
+// stay consistent across all of them.
+type deferredState struct {
+	mu         sync.Mutex
+	maxEntries int
+	buffered   []bufferedEntry
+	dropped    int
+	delegate   zapcore.Core
+}
+
+// DeferredLogger is a zapcore.Core that buffers entries in memory, bounded by
+// maxEntries with drop-oldest semantics, until SetDelegate installs the real
+// core. This lets an application register the ZapLogger middleware before its
+// zap/otelzap logger is fully wired (e.g. because its config depends on flags
+// parsed later, or on an OTel exporter that isn't ready yet) without losing
+// the log lines written in between.
+type DeferredLogger struct {
+	state  *deferredState
+	fields []zapcore.Field
+}
+
+// NewDeferredLogger returns a DeferredLogger that buffers up to maxEntries
+// log entries, dropping the oldest once full, until SetDelegate is called. A
+// maxEntries of 0 or less means unbounded buffering.
+func NewDeferredLogger(maxEntries int) *DeferredLogger {
+	return &DeferredLogger{state: &deferredState{maxEntries: maxEntries}}
+}
+
+// ZapLoggerDeferred returns a middleware that logs through a DeferredLogger,
+// along with that DeferredLogger so the application can install its real
+// core once it's ready. maxBufferedEntries bounds how many entries are
+// buffered (dropping the oldest) before that happens.
+func ZapLoggerDeferred(maxBufferedEntries int) (echo.MiddlewareFunc, *DeferredLogger) {
+	deferred := NewDeferredLogger(maxBufferedEntries)
+	return ZapLogger(zap.New(deferred)), deferred
+}
+
+// SetDelegate installs core as the real destination: buffered entries are
+// flushed to it in order, and every subsequent Write is forwarded directly.
+// Safe to call exactly once; later calls replace the delegate and do not
+// re-flush what has already been flushed.
+func (d *DeferredLogger) SetDelegate(core zapcore.Core) {
+	d.state.mu.Lock()
+	buffered := d.state.buffered
+	d.state.buffered = nil
+	d.state.delegate = core
+	d.state.mu.Unlock()
+
+	for _, be := range buffered {
+		if ce := core.Check(be.entry, nil); ce != nil {
+			ce.Write(be.fields...)
+		}
+	}
+}
+
+// delegate returns the installed core, if any.
+func (d *DeferredLogger) delegate() zapcore.Core {
+	d.state.mu.Lock()
+	defer d.state.mu.Unlock()
+	return d.state.delegate
+}
+
+// Enabled implements zapcore.Core.
+func (d *DeferredLogger) Enabled(level zapcore.Level) bool {
+	if core := d.delegate(); core != nil {
+		return core.Enabled(level)
+	}
+	return true
+}
+
+// With implements zapcore.Core.
+func (d *DeferredLogger) With(fields []zapcore.Field) zapcore.Core {
+	if core := d.delegate(); core != nil {
+		return core.With(d.fields).With(fields)
+	}
+	combined := make([]zapcore.Field, 0, len(d.fields)+len(fields))
+	combined = append(combined, d.fields...)
+	combined = append(combined, fields...)
+	return &DeferredLogger{state: d.state, fields: combined}
+}
+
+// Check implements zapcore.Core.
+func (d *DeferredLogger) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if core := d.delegate(); core != nil {
+		if len(d.fields) > 0 {
+			core = core.With(d.fields)
+		}
+		return core.Check(ent, ce)
+	}
+	if d.Enabled(ent.Level) {
+		return ce.AddCore(ent, d)
+	}
+	return ce
+}
+
+// Write implements zapcore.Core. Before a delegate is installed it buffers
+// the entry; once one is installed it forwards to it directly.
+func (d *DeferredLogger) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	d.state.mu.Lock()
+	if d.state.delegate == nil {
+		combined := make([]zapcore.Field, 0, len(d.fields)+len(fields))
+		combined = append(combined, d.fields...)
+		combined = append(combined, fields...)
+
+		if d.state.maxEntries > 0 && len(d.state.buffered) >= d.state.maxEntries {
+			copy(d.state.buffered, d.state.buffered[1:])
+			d.state.buffered = d.state.buffered[:len(d.state.buffered)-1]
+			d.state.dropped++
+		}
+		d.state.buffered = append(d.state.buffered, bufferedEntry{entry: ent, fields: combined})
+		d.state.mu.Unlock()
+		return nil
+	}
+	delegate := d.state.delegate
+	d.state.mu.Unlock()
+
+	return delegate.With(d.fields).Write(ent, fields)
+}
+
+// Sync implements zapcore.Core.
+func (d *DeferredLogger) Sync() error {
+	if core := d.delegate(); core != nil {
+		return core.Sync()
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package echozap
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestDeferredLogger_FlushOnSwap(t *testing.T) {
+	deferred := NewDeferredLogger(10)
+	log := zap.New(deferred)
+
+	log.Info("buffered one")
+	log.Info("buffered two")
+
+	core, logs := observer.New(zapcore.InfoLevel)
+	deferred.SetDelegate(core)
+
+	require.Equal(t, 2, logs.Len())
+	assert.Equal(t, "buffered one", logs.All()[0].Message)
+	assert.Equal(t, "buffered two", logs.All()[1].Message)
+
+	log.Info("after swap")
+	require.Equal(t, 3, logs.Len())
+	assert.Equal(t, "after swap", logs.All()[2].Message)
+}
+
+func TestDeferredLogger_OverflowDropsOldest(t *testing.T) {
+	deferred := NewDeferredLogger(2)
+	log := zap.New(deferred)
+
+	log.Info("first")
+	log.Info("second")
+	log.Info("third")
+
+	core, logs := observer.New(zapcore.InfoLevel)
+	deferred.SetDelegate(core)
+
+	require.Equal(t, 2, logs.Len())
+	assert.Equal(t, "second", logs.All()[0].Message)
+	assert.Equal(t, "third", logs.All()[1].Message)
+	assert.Equal(t, 1, deferred.state.dropped)
+}
+
+func TestDeferredLogger_WithPreservesFieldsAcrossDelegateSwap(t *testing.T) {
+	deferred := NewDeferredLogger(10)
+	log := zap.New(deferred).With(zap.String("a", "1"))
+
+	core, logs := observer.New(zapcore.InfoLevel)
+	deferred.SetDelegate(core)
+
+	log.With(zap.String("b", "2")).Info("after swap")
+
+	require.Equal(t, 1, logs.Len())
+	entry := logs.All()[0]
+	assert.Equal(t, "1", entry.ContextMap()["a"])
+	assert.Equal(t, "2", entry.ContextMap()["b"])
+}
+
+func TestDeferredLogger_ConcurrentWrites(t *testing.T) {
+	deferred := NewDeferredLogger(1000)
+	log := zap.New(deferred)
+
+	const goroutines = 50
+	const perGoroutine = 20
+
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+	for i := 0; i < goroutines; i++ {
+		go func() {
+			defer wg.Done()
+			for j := 0; j < perGoroutine; j++ {
+				log.Info("concurrent")
+			}
+		}()
+	}
+	wg.Wait()
+
+	core, logs := observer.New(zapcore.InfoLevel)
+	deferred.SetDelegate(core)
+
+	assert.Equal(t, goroutines*perGoroutine, logs.Len())
+
+	log.Info("after concurrent swap")
+	assert.Equal(t, goroutines*perGoroutine+1, logs.Len())
+}
@@ -1,15 +1,23 @@
 package echozap
 
 import (
-	"fmt"
+	"context"
+	"sync"
 	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/uptrace/opentelemetry-go-extra/otelzap"
+	otelzapbridge "go.opentelemetry.io/contrib/bridges/otelzap"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
 
+// instrumentationName identifies this middleware as the emitter of log
+// records passed through the OTel logs bridge.
+const instrumentationName = "github.com/J-Meyer42/echozap"
+
 type (
 	Skipper func(c echo.Context) bool
 
@@ -17,6 +25,30 @@ type (
 	ZapLoggerConfig struct {
 		Skipper         Skipper
 		CustomFieldFunc func(c echo.Context) []zapcore.Field
+
+		// BaggageKeys whitelists OTel baggage members to append as log fields,
+		// e.g. "session_id", "tenant", "user_id".
+		BaggageKeys []string
+
+		// TraceFields adds "trace_id" and "span_id" fields taken from the active
+		// OTel span on the request context, so access logs can be joined with
+		// traces in the backend.
+		TraceFields bool
+
+		// LevelFunc overrides the status-to-level mapping, e.g. to keep health
+		// checks at Debug or to stop expected 404s from escalating to Warn. It
+		// falls back to the default mapping (>=500 Error, >=400 Warn, else Info)
+		// when nil.
+		LevelFunc func(c echo.Context, status int, err error) zapcore.Level
+
+		// MessageFunc overrides the log message, which otherwise falls back to
+		// the default status-to-message mapping ("Server error", "Client error",
+		// "Redirection", "Success").
+		MessageFunc func(c echo.Context, status int) string
+
+		// SlowRequestThreshold promotes any request whose latency meets or
+		// exceeds it to at least Warn, regardless of status.
+		SlowRequestThreshold time.Duration
 	}
 )
 
@@ -37,15 +69,49 @@ func ZapLogger(log *zap.Logger) echo.MiddlewareFunc {
 	return ZapLoggerWithConfig(log, DefaultZapLoggerConfig)
 }
 
-// OtelZapLogger is a middleware and zap to provide an "access log" like logging and opentelemetry support for each request.
+// OtelZapLogger is a middleware and zap to provide an "access log" like
+// logging and opentelemetry support for each request, via the Uptrace
+// otelzap bridge. New applications should prefer OtelBridgeLogger, which
+// routes through the official OTel logs bridge instead.
 func OtelZapLogger(log *otelzap.Logger) echo.MiddlewareFunc {
 	return OtelZapLoggerWithConfig(log, DefaultZapLoggerConfig)
 }
 
-// ZapLoggerWithConfig is a middleware (with configuration) and zap to provide an "access log" like logging for each request.
-func ZapLoggerWithConfig(log *zap.Logger, config ZapLoggerConfig) echo.MiddlewareFunc {
+// OtelBridgeLogger is a middleware and zap to provide an "access log" like
+// logging for each request, routed through the official
+// go.opentelemetry.io/contrib/bridges/otelzap core rather than Uptrace's
+// otelzap bridge. Prefer this over OtelZapLogger for applications already on
+// the OTel logs API/SDK pipeline, since it lets them drop the dependency on
+// github.com/uptrace/opentelemetry-go-extra/otelzap entirely. opts are passed
+// through to the bridge's Core, e.g. otelzapbridge.WithLoggerProvider. Use
+// OtelBridgeLoggerWithConfig for Skipper, CustomFieldFunc, BaggageKeys, and
+// the other ZapLoggerConfig options.
+func OtelBridgeLogger(log *zap.Logger, opts ...otelzapbridge.Option) echo.MiddlewareFunc {
+	return OtelBridgeLoggerWithConfig(log, DefaultZapLoggerConfig, opts...)
+}
+
+// OtelBridgeLoggerWithConfig is OtelBridgeLogger with configuration, so the
+// OTel logs bridge path supports the same Skipper, CustomFieldFunc,
+// BaggageKeys, TraceFields, LevelFunc, MessageFunc, and SlowRequestThreshold
+// options as OtelZapLoggerWithConfig. ZapLoggerWithConfig supports the same
+// options except Skipper, which it does not apply.
+func OtelBridgeLoggerWithConfig(log *zap.Logger, config ZapLoggerConfig, opts ...otelzapbridge.Option) echo.MiddlewareFunc {
+	bridge := otelzapbridge.NewCore(instrumentationName, opts...)
+	composed := log.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return zapcore.NewTee(core, bridge)
+	}))
+
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		// Defaults
+		if config.Skipper == nil {
+			config.Skipper = DefaultZapLoggerConfig.Skipper
+		}
+
 		return func(c echo.Context) error {
+			if config.Skipper(c) {
+				return next(c)
+			}
+
 			start := time.Now()
 
 			err := next(c)
@@ -53,47 +119,200 @@ func ZapLoggerWithConfig(log *zap.Logger, config ZapLoggerConfig) echo.Middlewar
 				c.Error(err)
 			}
 
-			req := c.Request()
-			res := c.Response()
-
-			fields := []zapcore.Field{
-				zap.String("remote_ip", c.RealIP()),
-				zap.String("latency", time.Since(start).String()),
-				zap.String("host", req.Host),
-				zap.String("request", fmt.Sprintf("%s %s", req.Method, req.RequestURI)),
-				zap.Int("status", res.Status),
-				zap.Int64("size", res.Size),
-				zap.String("user_agent", req.UserAgent()),
-			}
+			// With clones the bridge core (and its request-scoped ctx) instead of
+			// mutating the shared one built above, so concurrent requests can't
+			// stomp on each other's trace context.
+			reqLog := composed.With(contextField(c.Request().Context()))
+			writeAccessLog(reqLog, c, config, c.Response().Status, err, time.Since(start))
 
-			id := req.Header.Get(echo.HeaderXRequestID)
-			if id == "" {
-				id = res.Header().Get(echo.HeaderXRequestID)
-			}
-			fields = append(fields, zap.String("request_id", id))
+			return nil
+		}
+	}
+}
 
-			// Append custom logger fields if provided
-			if config.CustomFieldFunc != nil {
-				fields = append(fields, config.CustomFieldFunc(c)...)
-			}
+// ZapLoggerWithConfig is a middleware (with configuration) and zap to provide an "access log" like logging for each request.
+func ZapLoggerWithConfig(log *zap.Logger, config ZapLoggerConfig) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
 
-			n := res.Status
-			switch {
-			case n >= 500:
-				log.With(zap.Error(err)).Error("Server error", fields...)
-			case n >= 400:
-				log.With(zap.Error(err)).Warn("Client error", fields...)
-			case n >= 300:
-				log.Info("Redirection", fields...)
-			default:
-				log.Info("Success", fields...)
+			err := next(c)
+			if err != nil {
+				c.Error(err)
 			}
 
+			writeAccessLog(log, c, config, c.Response().Status, err, time.Since(start))
+
 			return nil
 		}
 	}
 }
 
+// fieldsPool recycles the []zapcore.Field backing arrays built for each
+// request so logging a request doesn't allocate one on every call.
+var fieldsPool = sync.Pool{
+	New: func() any {
+		s := make([]zapcore.Field, 0, 8)
+		return &s
+	},
+}
+
+// acquireFields returns a pooled, zero-length []zapcore.Field slice. Pair
+// with releaseFields once the fields have been written.
+func acquireFields() *[]zapcore.Field {
+	return fieldsPool.Get().(*[]zapcore.Field)
+}
+
+func releaseFields(fields *[]zapcore.Field) {
+	*fields = (*fields)[:0]
+	fieldsPool.Put(fields)
+}
+
+// buildFields appends the standard access-log fields for a request/response
+// pair, plus any configured trace, baggage, and custom fields, onto fields.
+// It is shared by every constructor in this package so they stay consistent
+// as fields are added.
+func buildFields(fields []zapcore.Field, c echo.Context, latency time.Duration, config ZapLoggerConfig) []zapcore.Field {
+	req := c.Request()
+	res := c.Response()
+
+	fields = append(fields,
+		zap.String("remote_ip", c.RealIP()),
+		zap.Duration("latency", latency),
+		zap.String("host", req.Host),
+		zap.String("method", req.Method),
+		zap.String("uri", req.RequestURI),
+		zap.Int("status", res.Status),
+		zap.Int64("size", res.Size),
+		zap.String("user_agent", req.UserAgent()),
+	)
+
+	id := req.Header.Get(echo.HeaderXRequestID)
+	if id == "" {
+		id = res.Header().Get(echo.HeaderXRequestID)
+	}
+	fields = append(fields, zap.ByteString("request_id", []byte(id)))
+
+	fields = otelFields(fields, req.Context(), config)
+
+	// Append custom logger fields if provided
+	if config.CustomFieldFunc != nil {
+		fields = append(fields, config.CustomFieldFunc(c)...)
+	}
+
+	return fields
+}
+
+// defaultLevel is the status-to-level mapping used when
+// ZapLoggerConfig.LevelFunc is not set.
+func defaultLevel(status int) zapcore.Level {
+	switch {
+	case status >= 500:
+		return zapcore.ErrorLevel
+	case status >= 400:
+		return zapcore.WarnLevel
+	default:
+		return zapcore.InfoLevel
+	}
+}
+
+// defaultMessage is the status-to-message mapping used when
+// ZapLoggerConfig.MessageFunc is not set.
+func defaultMessage(status int) string {
+	switch {
+	case status >= 500:
+		return "Server error"
+	case status >= 400:
+		return "Client error"
+	case status >= 300:
+		return "Redirection"
+	default:
+		return "Success"
+	}
+}
+
+// decideLevel resolves the level for this entry: ZapLoggerConfig.LevelFunc
+// (or the default status mapping) promoted to at least Warn if the request
+// ran past SlowRequestThreshold.
+func decideLevel(c echo.Context, config ZapLoggerConfig, status int, err error, latency time.Duration) zapcore.Level {
+	level := defaultLevel(status)
+	if config.LevelFunc != nil {
+		level = config.LevelFunc(c, status, err)
+	}
+	if config.SlowRequestThreshold > 0 && latency >= config.SlowRequestThreshold && level < zapcore.WarnLevel {
+		level = zapcore.WarnLevel
+	}
+	return level
+}
+
+// decideMessage resolves the message for this entry: ZapLoggerConfig.MessageFunc
+// or the default status mapping.
+func decideMessage(c echo.Context, config ZapLoggerConfig, status int) string {
+	if config.MessageFunc != nil {
+		return config.MessageFunc(c, status)
+	}
+	return defaultMessage(status)
+}
+
+// writeAccessLog resolves the level and message for this entry and, if log is
+// enabled for that level, builds the fields and writes it. Resolving the
+// level first lets log.Check short-circuit disabled levels before any field
+// is built.
+func writeAccessLog(log *zap.Logger, c echo.Context, config ZapLoggerConfig, status int, err error, latency time.Duration, extra ...zapcore.Field) {
+	level := decideLevel(c, config, status, err, latency)
+	msg := decideMessage(c, config, status)
+
+	ce := log.Check(level, msg)
+	if ce == nil {
+		return
+	}
+
+	fp := acquireFields()
+	defer releaseFields(fp)
+
+	*fp = buildFields(*fp, c, latency, config)
+	*fp = append(*fp, extra...)
+	if level >= zapcore.WarnLevel && err != nil {
+		*fp = append(*fp, zap.Error(err))
+	}
+
+	ce.Write(*fp...)
+}
+
+// contextField wraps ctx as a no-op zap field: cores that understand it (the
+// OTel logs bridge) recover the request's trace context from it, while
+// standard encoders (console, JSON, ...) skip it since its type is
+// zapcore.SkipType.
+func contextField(ctx context.Context) zapcore.Field {
+	return zapcore.Field{Type: zapcore.SkipType, Interface: ctx}
+}
+
+// otelFields extracts the trace/span IDs and whitelisted baggage members from
+// ctx so access logs can be correlated with the trace and the tenant/session
+// that produced them.
+func otelFields(fields []zapcore.Field, ctx context.Context, config ZapLoggerConfig) []zapcore.Field {
+	if config.TraceFields {
+		sc := trace.SpanContextFromContext(ctx)
+		if sc.IsValid() {
+			fields = append(fields,
+				zap.String("trace_id", sc.TraceID().String()),
+				zap.String("span_id", sc.SpanID().String()),
+			)
+		}
+	}
+
+	if len(config.BaggageKeys) > 0 {
+		b := baggage.FromContext(ctx)
+		for _, key := range config.BaggageKeys {
+			if v := b.Member(key).Value(); v != "" {
+				fields = append(fields, zap.String(key, v))
+			}
+		}
+	}
+
+	return fields
+}
+
 // OtelZapLoggerWithConfig is a middleware (with configuration) and otelzap to provide an "access log" like logging and opentelemetry support for each request.
 func OtelZapLoggerWithConfig(log *otelzap.Logger, config ZapLoggerConfig) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
@@ -114,40 +333,41 @@ func OtelZapLoggerWithConfig(log *otelzap.Logger, config ZapLoggerConfig) echo.M
 				c.Error(err)
 			}
 
-			req := c.Request()
-			res := c.Response()
-
-			fields := []zapcore.Field{
-				zap.String("remote_ip", c.RealIP()),
-				zap.String("latency", time.Since(start).String()),
-				zap.String("host", req.Host),
-				zap.String("request", fmt.Sprintf("%s %s", req.Method, req.RequestURI)),
-				zap.Int("status", res.Status),
-				zap.Int64("size", res.Size),
-				zap.String("user_agent", req.UserAgent()),
-			}
+			latency := time.Since(start)
+			status := c.Response().Status
+			level := decideLevel(c, config, status, err, latency)
+			msg := decideMessage(c, config, status)
 
-			id := req.Header.Get(echo.HeaderXRequestID)
-			if id == "" {
-				id = res.Header().Get(echo.HeaderXRequestID)
+			// log embeds a *zap.Logger, so Check gates on the same core this
+			// entry would ultimately be written to.
+			if ce := log.Check(level, msg); ce == nil {
+				return nil
 			}
-			fields = append(fields, zap.String("request_id", id))
 
-			// Append custom logger fields if provided
-			if config.CustomFieldFunc != nil {
-				fields = append(fields, config.CustomFieldFunc(c)...)
+			fp := acquireFields()
+			defer releaseFields(fp)
+
+			*fp = buildFields(*fp, c, latency, config)
+			if level >= zapcore.WarnLevel && err != nil {
+				*fp = append(*fp, zap.Error(err))
 			}
 
-			n := res.Status
-			switch {
-			case n >= 500:
-				log.With(zap.Error(err)).Error("Server error", fields...)
-			case n >= 400:
-				log.With(zap.Error(err)).Warn("Client error", fields...)
-			case n >= 300:
-				log.Info("Redirection", fields...)
+			ctxLog := log.Ctx(c.Request().Context())
+			switch level {
+			case zapcore.DebugLevel:
+				ctxLog.Debug(msg, (*fp)...)
+			case zapcore.WarnLevel:
+				ctxLog.Warn(msg, (*fp)...)
+			case zapcore.ErrorLevel:
+				ctxLog.Error(msg, (*fp)...)
+			case zapcore.DPanicLevel:
+				ctxLog.DPanic(msg, (*fp)...)
+			case zapcore.PanicLevel:
+				ctxLog.Panic(msg, (*fp)...)
+			case zapcore.FatalLevel:
+				ctxLog.Fatal(msg, (*fp)...)
 			default:
-				log.Info("Success", fields...)
+				ctxLog.Info(msg, (*fp)...)
 			}
 
 			return nil
@@ -0,0 +1,54 @@
+package echozap
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// BenchmarkZapLoggerWithConfig demonstrates that log.Check lets a request
+// logged at a disabled level skip field construction almost entirely,
+// compared to a request that is actually written.
+func BenchmarkZapLoggerWithConfig(b *testing.B) {
+	next := func(c echo.Context) error {
+		return c.String(http.StatusOK, "ok")
+	}
+
+	cases := []struct {
+		name       string
+		coreLevel  zapcore.Level
+		wantStatus int
+	}{
+		{"Enabled", zapcore.InfoLevel, http.StatusOK},
+		{"Disabled", zapcore.ErrorLevel, http.StatusOK},
+	}
+
+	for _, tc := range cases {
+		b.Run(tc.name, func(b *testing.B) {
+			core := zapcore.NewCore(
+				zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()),
+				zapcore.AddSync(io.Discard),
+				tc.coreLevel,
+			)
+			mw := ZapLoggerWithConfig(zap.New(core), DefaultZapLoggerConfig)(next)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if err := mw(c); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
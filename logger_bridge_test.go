@@ -0,0 +1,86 @@
+package echozap
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	otelzapbridge "go.opentelemetry.io/contrib/bridges/otelzap"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// recordingExporter captures every emitted record's trace ID so the test can
+// check it against the request that produced it. sdklog.SimpleProcessor may
+// call Export from whichever goroutine emitted the record, so access is
+// guarded by a mutex.
+type recordingExporter struct {
+	mu      sync.Mutex
+	traceID map[string]bool
+}
+
+func (e *recordingExporter) Export(ctx context.Context, records []sdklog.Record) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, r := range records {
+		e.traceID[r.TraceID().String()] = true
+	}
+	return nil
+}
+
+func (e *recordingExporter) Shutdown(ctx context.Context) error   { return nil }
+func (e *recordingExporter) ForceFlush(ctx context.Context) error { return nil }
+
+func (e *recordingExporter) has(traceID string) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.traceID[traceID]
+}
+
+// TestOtelBridgeLoggerWithConfig_ConcurrentRequests exercises the bridge Core
+// shared by every request through a real log.LoggerProvider (rather than a
+// no-op one) so that Core.Write actually runs. Each request carries its own
+// trace ID; run with -race to catch the shared Core.ctx being mutated by
+// concurrent requests instead of cloned per request.
+func TestOtelBridgeLoggerWithConfig_ConcurrentRequests(t *testing.T) {
+	exporter := &recordingExporter{traceID: map[string]bool{}}
+	provider := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(exporter)))
+	defer provider.Shutdown(context.Background())
+
+	mw := OtelBridgeLoggerWithConfig(zap.NewNop(), ZapLoggerConfig{TraceFields: true},
+		otelzapbridge.WithLoggerProvider(provider))
+	handler := mw(func(c echo.Context) error { return c.String(http.StatusOK, "ok") })
+
+	const requests = 50
+	var wg sync.WaitGroup
+	wg.Add(requests)
+	for i := 0; i < requests; i++ {
+		go func(i int) {
+			defer wg.Done()
+
+			traceID, err := trace.TraceIDFromHex(fmt.Sprintf("%032x", i+1))
+			require.NoError(t, err)
+			spanID, err := trace.SpanIDFromHex(fmt.Sprintf("%016x", i+1))
+			require.NoError(t, err)
+			sc := trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID})
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req = req.WithContext(trace.ContextWithSpanContext(req.Context(), sc))
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			require.NoError(t, handler(c))
+
+			assert.True(t, exporter.has(traceID.String()), "trace %s was not recorded", traceID)
+		}(i)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,187 @@
+package echozap
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap/zapcore"
+)
+
+func newTestContext() echo.Context {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	return e.NewContext(req, rec)
+}
+
+func contextWithSpan(t *testing.T) context.Context {
+	t.Helper()
+	traceID, err := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	require.NoError(t, err)
+	spanID, err := trace.SpanIDFromHex("00f067aa0ba902b7")
+	require.NoError(t, err)
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	})
+	return trace.ContextWithSpanContext(context.Background(), sc)
+}
+
+func fieldMap(fields []zapcore.Field) map[string]zapcore.Field {
+	m := make(map[string]zapcore.Field, len(fields))
+	for _, f := range fields {
+		m[f.Key] = f
+	}
+	return m
+}
+
+func TestOtelFields_TraceFields(t *testing.T) {
+	ctx := contextWithSpan(t)
+
+	t.Run("disabled", func(t *testing.T) {
+		fields := otelFields(nil, ctx, ZapLoggerConfig{})
+		assert.Empty(t, fields)
+	})
+
+	t.Run("enabled with valid span", func(t *testing.T) {
+		fields := fieldMap(otelFields(nil, ctx, ZapLoggerConfig{TraceFields: true}))
+		require.Contains(t, fields, "trace_id")
+		require.Contains(t, fields, "span_id")
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", fields["trace_id"].String)
+		assert.Equal(t, "00f067aa0ba902b7", fields["span_id"].String)
+	})
+
+	t.Run("enabled without a span", func(t *testing.T) {
+		fields := otelFields(nil, context.Background(), ZapLoggerConfig{TraceFields: true})
+		assert.Empty(t, fields)
+	})
+}
+
+func TestOtelFields_BaggageKeys(t *testing.T) {
+	tenant, err := baggage.NewMember("tenant", "acme")
+	require.NoError(t, err)
+	b, err := baggage.New(tenant)
+	require.NoError(t, err)
+	ctx := baggage.ContextWithBaggage(context.Background(), b)
+
+	t.Run("only whitelisted keys are appended", func(t *testing.T) {
+		fields := fieldMap(otelFields(nil, ctx, ZapLoggerConfig{BaggageKeys: []string{"tenant", "session_id"}}))
+		require.Contains(t, fields, "tenant")
+		assert.Equal(t, "acme", fields["tenant"].String)
+		assert.NotContains(t, fields, "session_id")
+	})
+
+	t.Run("no keys configured", func(t *testing.T) {
+		fields := otelFields(nil, ctx, ZapLoggerConfig{})
+		assert.Empty(t, fields)
+	})
+}
+
+func TestDecideLevel_Default(t *testing.T) {
+	c := newTestContext()
+
+	cases := []struct {
+		status int
+		want   zapcore.Level
+	}{
+		{200, zapcore.InfoLevel},
+		{301, zapcore.InfoLevel},
+		{404, zapcore.WarnLevel},
+		{500, zapcore.ErrorLevel},
+	}
+	for _, tc := range cases {
+		got := decideLevel(c, ZapLoggerConfig{}, tc.status, nil, 0)
+		assert.Equal(t, tc.want, got, "status %d", tc.status)
+	}
+}
+
+func TestDecideLevel_LevelFuncOverride(t *testing.T) {
+	c := newTestContext()
+	config := ZapLoggerConfig{
+		LevelFunc: func(c echo.Context, status int, err error) zapcore.Level {
+			if status == 404 {
+				return zapcore.DebugLevel
+			}
+			return zapcore.InfoLevel
+		},
+	}
+
+	assert.Equal(t, zapcore.DebugLevel, decideLevel(c, config, 404, nil, 0))
+	assert.Equal(t, zapcore.InfoLevel, decideLevel(c, config, 200, nil, 0))
+}
+
+func TestDecideLevel_PropagatesErrToLevelFunc(t *testing.T) {
+	c := newTestContext()
+	wantErr := errors.New("boom")
+
+	config := ZapLoggerConfig{
+		LevelFunc: func(c echo.Context, status int, err error) zapcore.Level {
+			if err != nil {
+				return zapcore.ErrorLevel
+			}
+			return zapcore.InfoLevel
+		},
+	}
+
+	assert.Equal(t, zapcore.ErrorLevel, decideLevel(c, config, 200, wantErr, 0))
+	assert.Equal(t, zapcore.InfoLevel, decideLevel(c, config, 200, nil, 0))
+}
+
+func TestDecideLevel_SlowRequestThreshold(t *testing.T) {
+	c := newTestContext()
+	config := ZapLoggerConfig{SlowRequestThreshold: 100 * time.Millisecond}
+
+	t.Run("promotes a fast-status slow request to Warn", func(t *testing.T) {
+		got := decideLevel(c, config, http.StatusOK, nil, 200*time.Millisecond)
+		assert.Equal(t, zapcore.WarnLevel, got)
+	})
+
+	t.Run("leaves a request under the threshold alone", func(t *testing.T) {
+		got := decideLevel(c, config, http.StatusOK, nil, 50*time.Millisecond)
+		assert.Equal(t, zapcore.InfoLevel, got)
+	})
+
+	t.Run("does not downgrade a level already above Warn", func(t *testing.T) {
+		got := decideLevel(c, config, http.StatusInternalServerError, nil, 200*time.Millisecond)
+		assert.Equal(t, zapcore.ErrorLevel, got)
+	})
+
+	t.Run("a zero threshold disables the escalation", func(t *testing.T) {
+		got := decideLevel(c, ZapLoggerConfig{}, http.StatusOK, nil, time.Hour)
+		assert.Equal(t, zapcore.InfoLevel, got)
+	})
+}
+
+func TestDecideMessage(t *testing.T) {
+	c := newTestContext()
+
+	t.Run("default mapping", func(t *testing.T) {
+		assert.Equal(t, "Success", decideMessage(c, ZapLoggerConfig{}, 200))
+		assert.Equal(t, "Redirection", decideMessage(c, ZapLoggerConfig{}, 301))
+		assert.Equal(t, "Client error", decideMessage(c, ZapLoggerConfig{}, 404))
+		assert.Equal(t, "Server error", decideMessage(c, ZapLoggerConfig{}, 500))
+	})
+
+	t.Run("MessageFunc override", func(t *testing.T) {
+		config := ZapLoggerConfig{
+			MessageFunc: func(c echo.Context, status int) string {
+				if status == 404 {
+					return "not found"
+				}
+				return "handled"
+			},
+		}
+		assert.Equal(t, "not found", decideMessage(c, config, 404))
+		assert.Equal(t, "handled", decideMessage(c, config, 200))
+	})
+}